@@ -0,0 +1,97 @@
+package main
+
+import (
+    "context"
+    "database/sql"
+    "log"
+    "net/http"
+    "os"
+    "os/signal"
+    "syscall"
+    "time"
+
+    _ "github.com/lib/pq"
+    "github.com/prometheus/client_golang/prometheus"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/config"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/handler"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/migrate"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/repository"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/service"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/worker"
+)
+
+func main() {
+    cfg := config.Load()
+
+    db, err := sql.Open("postgres", cfg.DSN)
+    if err != nil {
+        log.Fatal(err)
+    }
+    db.SetMaxOpenConns(cfg.MaxOpenConns)
+    db.SetMaxIdleConns(cfg.MaxIdleConns)
+    db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+    for i := 0; i < 10; i++ {
+        if err := db.Ping(); err == nil {
+            break
+        }
+        log.Println("waiting for db...", i)
+        time.Sleep(1 * time.Second)
+    }
+
+    // `server migrate` applies versioned migrations and exits, for use in a
+    // deploy step ahead of starting the server.
+    if len(os.Args) > 1 && os.Args[1] == "migrate" {
+        if err := migrate.Run(context.Background(), db); err != nil {
+            log.Fatal(err)
+        }
+        return
+    }
+
+    if cfg.AutoMigrate {
+        if err := migrate.Run(context.Background(), db); err != nil {
+            log.Fatal(err)
+        }
+    }
+
+    metrics := service.NewMetrics(prometheus.DefaultRegisterer)
+
+    orderRepo := repository.NewPostgresOrderRepo(db)
+    taskRepo := repository.NewPostgresTaskRepo(db)
+
+    pool := worker.NewPool(taskRepo, orderRepo, cfg.OrderWorkers)
+
+    workerCtx, stopWorkers := context.WithCancel(context.Background())
+    defer stopWorkers()
+    go pool.Run(workerCtx)
+
+    userSvc := service.NewUserService(repository.NewPostgresUserRepo(db), metrics, cfg)
+    orderSvc := service.NewOrderService(orderRepo, taskRepo, pool, metrics, cfg)
+
+    router := handler.NewRouter(handler.NewUserHandler(userSvc), handler.NewOrderHandler(orderSvc))
+
+    srv := &http.Server{
+        Addr:    ":8081",
+        Handler: router,
+    }
+
+    go func() {
+        log.Println("listening on", srv.Addr)
+        if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+            log.Fatal(err)
+        }
+    }()
+
+    stop := make(chan os.Signal, 1)
+    signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+    <-stop
+
+    log.Println("shutting down...")
+    stopWorkers()
+    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+    defer cancel()
+    if err := srv.Shutdown(ctx); err != nil {
+        log.Fatal(err)
+    }
+}
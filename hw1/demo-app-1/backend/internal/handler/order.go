@@ -0,0 +1,124 @@
+package handler
+
+import (
+    "database/sql"
+    "encoding/json"
+    "net/http"
+    "strconv"
+
+    "github.com/gorilla/mux"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/repository"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/service"
+)
+
+// OrderHandler exposes the order endpoints. It calls the concrete
+// *service.OrderService, not an interface, and never builds SQL or touches a
+// driver directly.
+type OrderHandler struct {
+    svc *service.OrderService
+}
+
+func NewOrderHandler(svc *service.OrderService) *OrderHandler {
+    return &OrderHandler{svc: svc}
+}
+
+func (h *OrderHandler) Create(w http.ResponseWriter, r *http.Request) {
+    var o model.Order
+    if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+        http.Error(w, "bad request", http.StatusBadRequest)
+        return
+    }
+    if err := h.svc.CreateOrder(r.Context(), &o); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(o)
+}
+
+func (h *OrderHandler) List(w http.ResponseWriter, r *http.Request) {
+    params, err := parseListParams(r, repository.OrderSortColumns, "id")
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    var userID *int
+    if v := r.URL.Query().Get("user_id"); v != "" {
+        id, err := strconv.Atoi(v)
+        if err != nil {
+            http.Error(w, "invalid user_id", http.StatusBadRequest)
+            return
+        }
+        userID = &id
+    }
+
+    orders, total, err := h.svc.ListOrders(r.Context(), repository.OrderListParams{
+        ListParams: params,
+        UserID:     userID,
+    })
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+
+    writePaginationHeaders(w, r, params, total)
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(orders)
+}
+
+func (h *OrderHandler) Get(w http.ResponseWriter, r *http.Request) {
+    id, _ := strconv.Atoi(mux.Vars(r)["id"])
+    o, err := h.svc.GetOrder(r.Context(), id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            http.Error(w, "not found", http.StatusNotFound)
+            return
+        }
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(o)
+}
+
+func (h *OrderHandler) Update(w http.ResponseWriter, r *http.Request) {
+    id, _ := strconv.Atoi(mux.Vars(r)["id"])
+    var o model.Order
+    if err := json.NewDecoder(r.Body).Decode(&o); err != nil {
+        http.Error(w, "bad request", http.StatusBadRequest)
+        return
+    }
+    o.ID = id
+    if err := h.svc.UpdateOrder(r.Context(), &o); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrderHandler) Delete(w http.ResponseWriter, r *http.Request) {
+    id, _ := strconv.Atoi(mux.Vars(r)["id"])
+    if err := h.svc.DeleteOrder(r.Context(), id); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrderHandler) Status(w http.ResponseWriter, r *http.Request) {
+    id, _ := strconv.Atoi(mux.Vars(r)["id"])
+    status, err := h.svc.OrderStatus(r.Context(), id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            http.Error(w, "not found", http.StatusNotFound)
+            return
+        }
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(map[string]string{"status": string(status)})
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+    "fmt"
+    "net/http"
+    "net/url"
+    "strconv"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/repository"
+)
+
+// parseListParams reads limit/offset/sort_column/sort_order from the query
+// string and validates sort_column against columns.
+func parseListParams(r *http.Request, columns []string, defaultColumn string) (repository.ListParams, error) {
+    q := r.URL.Query()
+
+    params := repository.ListParams{
+        SortColumn: q.Get("sort_column"),
+        SortOrder:  q.Get("sort_order"),
+    }
+    if v := q.Get("limit"); v != "" {
+        limit, err := strconv.Atoi(v)
+        if err != nil {
+            return params, fmt.Errorf("invalid limit %q", v)
+        }
+        params.Limit = limit
+    }
+    if v := q.Get("offset"); v != "" {
+        offset, err := strconv.Atoi(v)
+        if err != nil {
+            return params, fmt.Errorf("invalid offset %q", v)
+        }
+        params.Offset = offset
+    }
+
+    if err := params.Normalize(columns, defaultColumn); err != nil {
+        return params, err
+    }
+    return params, nil
+}
+
+// writePaginationHeaders sets X-Total-Count and a Link header with
+// rel="next"/"prev" entries for the page described by params.
+func writePaginationHeaders(w http.ResponseWriter, r *http.Request, params repository.ListParams, total int) {
+    w.Header().Set("X-Total-Count", strconv.Itoa(total))
+
+    links := []string{}
+    if params.Offset+params.Limit < total {
+        links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(r, params.Offset+params.Limit, params.Limit)))
+    }
+    if params.Offset > 0 {
+        prevOffset := params.Offset - params.Limit
+        if prevOffset < 0 {
+            prevOffset = 0
+        }
+        links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(r, prevOffset, params.Limit)))
+    }
+    if len(links) > 0 {
+        header := links[0]
+        for _, l := range links[1:] {
+            header += ", " + l
+        }
+        w.Header().Set("Link", header)
+    }
+}
+
+func pageURL(r *http.Request, offset, limit int) string {
+    u := *r.URL
+    q := u.Query()
+    q.Set("offset", strconv.Itoa(offset))
+    q.Set("limit", strconv.Itoa(limit))
+    u.RawQuery = q.Encode()
+    return (&url.URL{Path: u.Path, RawQuery: u.RawQuery}).String()
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/gorilla/mux"
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+    requestDuration = prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name:    "http_request_duration_seconds",
+            Help:    "HTTP request durations",
+            Buckets: prometheus.DefBuckets,
+        },
+        []string{"method", "route", "status"},
+    )
+
+    requestCount = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "http_requests_total",
+            Help: "Total HTTP requests",
+        },
+        []string{"method", "route", "status"},
+    )
+)
+
+func init() {
+    prometheus.MustRegister(requestDuration, requestCount)
+}
+
+// NewRouter wires the user and order handlers onto their routes and
+// instruments every request with the matched route template.
+func NewRouter(users *UserHandler, orders *OrderHandler) http.Handler {
+    r := mux.NewRouter()
+    r.Use(instrumentMiddleware)
+    // mux only runs Use() middleware for matched routes; a true 404 falls
+    // through NotFoundHandler directly, bypassing it. Wrap that path too so
+    // unmatched requests still get recorded, under the "unmatched" label.
+    r.NotFoundHandler = instrumentMiddleware(http.HandlerFunc(http.NotFound))
+
+    api := r.PathPrefix("/api").Subrouter()
+
+    api.HandleFunc("/users", users.Create).Methods("POST")
+    api.HandleFunc("/users", users.List).Methods("GET")
+    api.HandleFunc("/users/{id}", users.Get).Methods("GET")
+    api.HandleFunc("/users/{id}", users.Update).Methods("PUT")
+    api.HandleFunc("/users/{id}", users.Delete).Methods("DELETE")
+
+    api.HandleFunc("/orders", orders.Create).Methods("POST")
+    api.HandleFunc("/orders", orders.List).Methods("GET")
+    api.HandleFunc("/orders/{id}", orders.Get).Methods("GET")
+    api.HandleFunc("/orders/{id}", orders.Update).Methods("PUT")
+    api.HandleFunc("/orders/{id}", orders.Delete).Methods("DELETE")
+    api.HandleFunc("/orders/{id}/status", orders.Status).Methods("GET")
+
+    r.Handle("/metrics", promhttp.Handler())
+
+    return r
+}
+
+// instrumentMiddleware records request duration and count labeled by the
+// matched route template rather than the raw URL path, so a metric like
+// /api/users/{id} has one label value no matter how many distinct IDs are
+// requested. Requests that match no route (404s) are labeled "unmatched".
+func instrumentMiddleware(next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        start := time.Now()
+        rw := &statusRecorder{ResponseWriter: w, status: 200}
+        next.ServeHTTP(rw, r)
+        dur := time.Since(start).Seconds()
+
+        route := "unmatched"
+        if matched := mux.CurrentRoute(r); matched != nil {
+            if tmpl, err := matched.GetPathTemplate(); err == nil {
+                route = tmpl
+            }
+        }
+
+        requestDuration.WithLabelValues(r.Method, route, strconv.Itoa(rw.status)).Observe(dur)
+        requestCount.WithLabelValues(r.Method, route, strconv.Itoa(rw.status)).Inc()
+    })
+}
+
+type statusRecorder struct {
+    http.ResponseWriter
+    status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+    r.status = code
+    r.ResponseWriter.WriteHeader(code)
+}
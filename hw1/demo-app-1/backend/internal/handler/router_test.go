@@ -0,0 +1,74 @@
+package handler
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "testing"
+
+    "github.com/gorilla/mux"
+    "github.com/prometheus/client_golang/prometheus"
+    dto "github.com/prometheus/client_model/go"
+)
+
+// countLabelValues returns the distinct "route" label values seen on
+// requestCount across all of its child metrics.
+func countLabelValues(t *testing.T) map[string]struct{} {
+    t.Helper()
+    mf := &dto.MetricFamily{}
+    gathered, err := prometheus.DefaultGatherer.Gather()
+    if err != nil {
+        t.Fatalf("gather metrics: %v", err)
+    }
+    values := map[string]struct{}{}
+    for _, f := range gathered {
+        if f.GetName() != "http_requests_total" {
+            continue
+        }
+        mf = f
+        for _, m := range mf.GetMetric() {
+            for _, l := range m.GetLabel() {
+                if l.GetName() == "route" {
+                    values[l.GetValue()] = struct{}{}
+                }
+            }
+        }
+    }
+    return values
+}
+
+func TestInstrumentMiddleware_BoundedCardinality(t *testing.T) {
+    ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+    r := mux.NewRouter()
+    r.Use(instrumentMiddleware)
+    r.NotFoundHandler = instrumentMiddleware(http.HandlerFunc(http.NotFound))
+    r.HandleFunc("/api/users/{id}", ok).Methods("GET")
+    router := http.Handler(r)
+
+    // Many distinct user IDs should all collapse onto the same route
+    // template label instead of producing one series per ID.
+    for _, id := range []string{"1", "2", "3", "42", "999999"} {
+        req := httptest.NewRequest(http.MethodGet, "/api/users/"+id, nil)
+        rec := httptest.NewRecorder()
+        router.ServeHTTP(rec, req)
+    }
+
+    // An unmatched path should be labeled "unmatched", not its raw value.
+    req := httptest.NewRequest(http.MethodGet, "/does/not/exist", nil)
+    rec := httptest.NewRecorder()
+    router.ServeHTTP(rec, req)
+
+    values := countLabelValues(t)
+    if _, ok := values["/api/users/{id}"]; !ok {
+        t.Fatalf("expected route template label, got %v", values)
+    }
+    if _, ok := values["/api/users/1"]; ok {
+        t.Fatalf("raw path leaked into route label: %v", values)
+    }
+    if _, ok := values["/does/not/exist"]; ok {
+        t.Fatalf("unmatched request used raw path as label: %v", values)
+    }
+    if _, ok := values["unmatched"]; !ok {
+        t.Fatalf("expected \"unmatched\" route label for 404s, got %v", values)
+    }
+}
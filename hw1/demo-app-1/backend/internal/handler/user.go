@@ -0,0 +1,99 @@
+package handler
+
+import (
+    "database/sql"
+    "encoding/json"
+    "net/http"
+    "strconv"
+
+    "github.com/gorilla/mux"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/repository"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/service"
+)
+
+// UserHandler exposes the user endpoints. It calls the concrete
+// *service.UserService, not an interface, and never builds SQL or touches a
+// driver directly.
+type UserHandler struct {
+    svc *service.UserService
+}
+
+func NewUserHandler(svc *service.UserService) *UserHandler {
+    return &UserHandler{svc: svc}
+}
+
+func (h *UserHandler) Create(w http.ResponseWriter, r *http.Request) {
+    var u model.User
+    if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+        http.Error(w, "bad request", http.StatusBadRequest)
+        return
+    }
+    if err := h.svc.CreateUser(r.Context(), &u); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(u)
+}
+
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+    params, err := parseListParams(r, repository.UserSortColumns, "id")
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+
+    users, total, err := h.svc.ListUsers(r.Context(), repository.UserListParams{
+        ListParams:  params,
+        EmailPrefix: r.URL.Query().Get("email"),
+    })
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+
+    writePaginationHeaders(w, r, params, total)
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(users)
+}
+
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+    id, _ := strconv.Atoi(mux.Vars(r)["id"])
+    u, err := h.svc.GetUser(r.Context(), id)
+    if err != nil {
+        if err == sql.ErrNoRows {
+            http.Error(w, "not found", http.StatusNotFound)
+            return
+        }
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(u)
+}
+
+func (h *UserHandler) Update(w http.ResponseWriter, r *http.Request) {
+    id, _ := strconv.Atoi(mux.Vars(r)["id"])
+    var u model.User
+    if err := json.NewDecoder(r.Body).Decode(&u); err != nil {
+        http.Error(w, "bad request", http.StatusBadRequest)
+        return
+    }
+    u.ID = id
+    if err := h.svc.UpdateUser(r.Context(), &u); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+    id, _ := strconv.Atoi(mux.Vars(r)["id"])
+    if err := h.svc.DeleteUser(r.Context(), id); err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
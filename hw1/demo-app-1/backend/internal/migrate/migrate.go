@@ -0,0 +1,81 @@
+// Package migrate applies versioned SQL migrations. It's the production
+// path for standing up the schema; Run is safe to call repeatedly since
+// applied versions are tracked in schema_migrations and already-applied
+// files are skipped.
+package migrate
+
+import (
+    "context"
+    "database/sql"
+    "embed"
+    "fmt"
+    "sort"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Run applies every migration under migrations/ that isn't already recorded
+// in schema_migrations, in filename order, each in its own transaction.
+func Run(ctx context.Context, db *sql.DB) error {
+    if err := ensureMigrationsTable(ctx, db); err != nil {
+        return err
+    }
+
+    entries, err := migrationFiles.ReadDir("migrations")
+    if err != nil {
+        return err
+    }
+    names := make([]string, 0, len(entries))
+    for _, e := range entries {
+        names = append(names, e.Name())
+    }
+    sort.Strings(names)
+
+    for _, name := range names {
+        applied, err := isApplied(ctx, db, name)
+        if err != nil {
+            return err
+        }
+        if applied {
+            continue
+        }
+
+        sqlBytes, err := migrationFiles.ReadFile("migrations/" + name)
+        if err != nil {
+            return err
+        }
+
+        tx, err := db.BeginTx(ctx, nil)
+        if err != nil {
+            return err
+        }
+        if _, err := tx.ExecContext(ctx, string(sqlBytes)); err != nil {
+            tx.Rollback()
+            return fmt.Errorf("migration %s: %w", name, err)
+        }
+        if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", name); err != nil {
+            tx.Rollback()
+            return fmt.Errorf("migration %s: record version: %w", name, err)
+        }
+        if err := tx.Commit(); err != nil {
+            return fmt.Errorf("migration %s: %w", name, err)
+        }
+    }
+    return nil
+}
+
+func ensureMigrationsTable(ctx context.Context, db *sql.DB) error {
+    _, err := db.ExecContext(ctx, `
+        CREATE TABLE IF NOT EXISTS schema_migrations (
+            version    TEXT PRIMARY KEY,
+            applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+        )`)
+    return err
+}
+
+func isApplied(ctx context.Context, db *sql.DB, version string) (bool, error) {
+    var exists bool
+    err := db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM schema_migrations WHERE version=$1)", version).Scan(&exists)
+    return exists, err
+}
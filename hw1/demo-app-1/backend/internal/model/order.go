@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// Order is a purchase placed by a User. Status tracks async enrichment
+// performed by the task queue after creation.
+type Order struct {
+    ID          int       `json:"id"`
+    UserID      int       `json:"user_id"`
+    Amount      float64   `json:"amount"`
+    Description string    `json:"description"`
+    Status      string    `json:"status"`
+    CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// TaskStatus is the lifecycle state of a background Task.
+type TaskStatus string
+
+const (
+    TaskStatusQueued     TaskStatus = "queued"
+    TaskStatusProcessing TaskStatus = "processing"
+    TaskStatusDone       TaskStatus = "done"
+    TaskStatusFailed     TaskStatus = "failed"
+)
+
+// MaxTaskAttempts is how many times a task is tried, its first run included,
+// before it's given up on and left in TaskStatusFailed for good.
+const MaxTaskAttempts = 5
+
+// Task is a unit of asynchronous work, e.g. "order.process" for a
+// newly created Order.
+type Task struct {
+    ID        int
+    Kind      string
+    OrderID   int
+    Status    TaskStatus
+    NextRun   time.Time
+    Attempts  int
+    LastError string
+    CreatedAt time.Time
+}
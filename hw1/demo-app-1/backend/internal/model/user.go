@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// User is a registered account in the system.
+type User struct {
+    ID        int       `json:"id"`
+    Name      string    `json:"name"`
+    Email     string    `json:"email"`
+    CreatedAt time.Time `json:"created_at"`
+}
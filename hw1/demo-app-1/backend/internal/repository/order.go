@@ -0,0 +1,113 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+)
+
+// OrderSortColumns is the whitelist of columns OrderRepository.List may sort by.
+var OrderSortColumns = []string{"id", "user_id", "amount", "created_at"}
+
+// OrderListParams are the filters accepted by OrderRepository.List, on top
+// of the shared ListParams pagination/sorting.
+type OrderListParams struct {
+    ListParams
+    UserID *int
+}
+
+// OrderRepository is the storage interface for Order records.
+type OrderRepository interface {
+    Create(ctx context.Context, o *model.Order) error
+    Get(ctx context.Context, id int) (*model.Order, error)
+    List(ctx context.Context, params OrderListParams) ([]model.Order, int, error)
+    Update(ctx context.Context, o *model.Order) error
+    Delete(ctx context.Context, id int) error
+    UpdateStatus(ctx context.Context, id int, status string) error
+}
+
+// PostgresOrderRepo implements OrderRepository backed by database/sql.
+type PostgresOrderRepo struct {
+    db *sql.DB
+}
+
+func NewPostgresOrderRepo(db *sql.DB) *PostgresOrderRepo {
+    return &PostgresOrderRepo{db: db}
+}
+
+func (r *PostgresOrderRepo) Create(ctx context.Context, o *model.Order) error {
+    o.Status = "pending"
+    return r.db.QueryRowContext(ctx,
+        "INSERT INTO orders (user_id, amount, description, status) VALUES ($1,$2,$3,$4) RETURNING id, created_at",
+        o.UserID, o.Amount, o.Description, o.Status,
+    ).Scan(&o.ID, &o.CreatedAt)
+}
+
+func (r *PostgresOrderRepo) Get(ctx context.Context, id int) (*model.Order, error) {
+    var o model.Order
+    err := r.db.QueryRowContext(ctx,
+        "SELECT id, user_id, amount, description, status, created_at FROM orders WHERE id=$1", id,
+    ).Scan(&o.ID, &o.UserID, &o.Amount, &o.Description, &o.Status, &o.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return &o, nil
+}
+
+// List returns the page of orders matching params, plus the total count of
+// matching rows (ignoring Limit/Offset) for the X-Total-Count header.
+func (r *PostgresOrderRepo) List(ctx context.Context, params OrderListParams) ([]model.Order, int, error) {
+    where := ""
+    args := []interface{}{}
+    if params.UserID != nil {
+        where = "WHERE user_id = $1"
+        args = append(args, *params.UserID)
+    }
+
+    var total int
+    countQuery := fmt.Sprintf("SELECT count(*) FROM orders %s", where)
+    if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+        return nil, 0, err
+    }
+
+    limitArg := len(args) + 1
+    offsetArg := len(args) + 2
+    query := fmt.Sprintf(
+        "SELECT id, user_id, amount, description, status, created_at FROM orders %s %s LIMIT $%d OFFSET $%d",
+        where, params.OrderByClause(), limitArg, offsetArg,
+    )
+    args = append(args, params.Limit, params.Offset)
+
+    rows, err := r.db.QueryContext(ctx, query, args...)
+    if err != nil {
+        return nil, 0, err
+    }
+    defer rows.Close()
+
+    orders := []model.Order{}
+    for rows.Next() {
+        var o model.Order
+        if err := rows.Scan(&o.ID, &o.UserID, &o.Amount, &o.Description, &o.Status, &o.CreatedAt); err != nil {
+            return nil, 0, err
+        }
+        orders = append(orders, o)
+    }
+    return orders, total, rows.Err()
+}
+
+func (r *PostgresOrderRepo) Update(ctx context.Context, o *model.Order) error {
+    _, err := r.db.ExecContext(ctx, "UPDATE orders SET user_id=$1, amount=$2, description=$3 WHERE id=$4", o.UserID, o.Amount, o.Description, o.ID)
+    return err
+}
+
+func (r *PostgresOrderRepo) Delete(ctx context.Context, id int) error {
+    _, err := r.db.ExecContext(ctx, "DELETE FROM orders WHERE id=$1", id)
+    return err
+}
+
+func (r *PostgresOrderRepo) UpdateStatus(ctx context.Context, id int, status string) error {
+    _, err := r.db.ExecContext(ctx, "UPDATE orders SET status=$1 WHERE id=$2", status, id)
+    return err
+}
@@ -0,0 +1,67 @@
+package repository
+
+import "fmt"
+
+const (
+    MaxLimit     = 1000
+    DefaultLimit = 100
+)
+
+// ListParams are the pagination/sorting options shared by the list
+// endpoints. SortColumn must be validated against a per-entity whitelist
+// before it is used to build SQL, since it is the one field that ends up
+// concatenated into a query string.
+type ListParams struct {
+    Limit      int
+    Offset     int
+    SortColumn string
+    SortOrder  string
+}
+
+// Normalize fills in defaults, caps Limit, and validates SortColumn against
+// columns (the whitelist of columns the caller may sort by) and SortOrder
+// against asc/desc. It returns an error naming the invalid field so the
+// handler can turn it into a 400.
+func (p *ListParams) Normalize(columns []string, defaultColumn string) error {
+    if p.Limit <= 0 {
+        p.Limit = DefaultLimit
+    }
+    if p.Limit > MaxLimit {
+        p.Limit = MaxLimit
+    }
+    if p.Offset < 0 {
+        p.Offset = 0
+    }
+
+    if p.SortColumn == "" {
+        p.SortColumn = defaultColumn
+    } else if !contains(columns, p.SortColumn) {
+        return fmt.Errorf("invalid sort_column %q", p.SortColumn)
+    }
+
+    switch p.SortOrder {
+    case "":
+        p.SortOrder = "desc"
+    case "asc", "desc":
+    default:
+        return fmt.Errorf("invalid sort_order %q", p.SortOrder)
+    }
+    return nil
+}
+
+// OrderByClause renders "ORDER BY <column> <ASC|DESC>". SortColumn and
+// SortOrder must already have passed Normalize, so they're safe to
+// concatenate directly: SortColumn was checked against the whitelist and
+// SortOrder against the asc/desc switch above.
+func (p ListParams) OrderByClause() string {
+    return fmt.Sprintf("ORDER BY %s %s", p.SortColumn, p.SortOrder)
+}
+
+func contains(values []string, target string) bool {
+    for _, v := range values {
+        if v == target {
+            return true
+        }
+    }
+    return false
+}
@@ -0,0 +1,47 @@
+package repository
+
+import "testing"
+
+func TestListParams_Normalize(t *testing.T) {
+    columns := []string{"id", "name", "email", "created_at"}
+
+    tests := []struct {
+        name    string
+        params  ListParams
+        wantErr bool
+    }{
+        {name: "defaults applied", params: ListParams{}, wantErr: false},
+        {name: "valid column and order", params: ListParams{SortColumn: "email", SortOrder: "asc"}, wantErr: false},
+        {name: "unknown column rejected", params: ListParams{SortColumn: "password"}, wantErr: true},
+        {name: "injection via column rejected", params: ListParams{SortColumn: "id; DROP TABLE users;--"}, wantErr: true},
+        {name: "injection via subquery rejected", params: ListParams{SortColumn: "(SELECT 1)"}, wantErr: true},
+        {name: "injection via order rejected", params: ListParams{SortOrder: "asc; DROP TABLE users;--"}, wantErr: true},
+        {name: "limit capped", params: ListParams{Limit: 50000}, wantErr: false},
+        {name: "negative offset clamped", params: ListParams{Offset: -5}, wantErr: false},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            p := tt.params
+            err := p.Normalize(columns, "id")
+            if tt.wantErr {
+                if err == nil {
+                    t.Fatalf("expected error for %+v, got none", tt.params)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("unexpected error: %v", err)
+            }
+            if p.Limit <= 0 || p.Limit > MaxLimit {
+                t.Fatalf("limit not normalized: %d", p.Limit)
+            }
+            if p.Offset < 0 {
+                t.Fatalf("offset not normalized: %d", p.Offset)
+            }
+            if p.SortOrder != "asc" && p.SortOrder != "desc" {
+                t.Fatalf("sort order not normalized: %q", p.SortOrder)
+            }
+        })
+    }
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "time"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+)
+
+// TaskRepository persists and hands out background tasks. ClaimNext uses
+// SELECT ... FOR UPDATE SKIP LOCKED so several server replicas can pull
+// from the same queue without double-processing a task.
+type TaskRepository interface {
+    Enqueue(ctx context.Context, kind string, orderID int) (*model.Task, error)
+    ClaimNext(ctx context.Context) (*model.Task, error)
+    MarkDone(ctx context.Context, id int) error
+    // MarkFailed records cause against task and, while task.Attempts is
+    // below MaxTaskAttempts, requeues it with a backoff; once attempts are
+    // exhausted it's left in TaskStatusFailed for good.
+    MarkFailed(ctx context.Context, task *model.Task, cause error) error
+    StatusByOrderID(ctx context.Context, orderID int) (model.TaskStatus, error)
+    QueueDepth(ctx context.Context) (int, error)
+}
+
+// PostgresTaskRepo implements TaskRepository backed by database/sql.
+type PostgresTaskRepo struct {
+    db *sql.DB
+}
+
+func NewPostgresTaskRepo(db *sql.DB) *PostgresTaskRepo {
+    return &PostgresTaskRepo{db: db}
+}
+
+func (r *PostgresTaskRepo) Enqueue(ctx context.Context, kind string, orderID int) (*model.Task, error) {
+    t := &model.Task{Kind: kind, OrderID: orderID, Status: model.TaskStatusQueued}
+    err := r.db.QueryRowContext(ctx,
+        "INSERT INTO tasks (kind, order_id, status, next_run, attempts) VALUES ($1, $2, $3, now(), 0) RETURNING id, next_run, created_at",
+        kind, orderID, model.TaskStatusQueued,
+    ).Scan(&t.ID, &t.NextRun, &t.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return t, nil
+}
+
+func (r *PostgresTaskRepo) ClaimNext(ctx context.Context) (*model.Task, error) {
+    tx, err := r.db.BeginTx(ctx, nil)
+    if err != nil {
+        return nil, err
+    }
+    defer tx.Rollback()
+
+    var t model.Task
+    var lastError sql.NullString
+    err = tx.QueryRowContext(ctx,
+        `SELECT id, kind, order_id, status, next_run, attempts, last_error, created_at
+         FROM tasks
+         WHERE status = $1 AND next_run <= now()
+         ORDER BY next_run
+         FOR UPDATE SKIP LOCKED
+         LIMIT 1`,
+        model.TaskStatusQueued,
+    ).Scan(&t.ID, &t.Kind, &t.OrderID, &t.Status, &t.NextRun, &t.Attempts, &lastError, &t.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    t.LastError = lastError.String
+
+    if _, err := tx.ExecContext(ctx, "UPDATE tasks SET status=$1, attempts=attempts+1 WHERE id=$2", model.TaskStatusProcessing, t.ID); err != nil {
+        return nil, err
+    }
+    if err := tx.Commit(); err != nil {
+        return nil, err
+    }
+
+    t.Status = model.TaskStatusProcessing
+    t.Attempts++
+    return &t, nil
+}
+
+func (r *PostgresTaskRepo) MarkDone(ctx context.Context, id int) error {
+    _, err := r.db.ExecContext(ctx, "UPDATE tasks SET status=$1 WHERE id=$2", model.TaskStatusDone, id)
+    return err
+}
+
+func (r *PostgresTaskRepo) MarkFailed(ctx context.Context, task *model.Task, cause error) error {
+    status := model.TaskStatusQueued
+    if task.Attempts >= model.MaxTaskAttempts {
+        status = model.TaskStatusFailed
+    }
+    _, err := r.db.ExecContext(ctx,
+        "UPDATE tasks SET status=$1, last_error=$2, next_run=$3 WHERE id=$4",
+        status, cause.Error(), time.Now().Add(time.Minute), task.ID,
+    )
+    return err
+}
+
+func (r *PostgresTaskRepo) StatusByOrderID(ctx context.Context, orderID int) (model.TaskStatus, error) {
+    var status model.TaskStatus
+    err := r.db.QueryRowContext(ctx,
+        "SELECT status FROM tasks WHERE order_id=$1 ORDER BY id DESC LIMIT 1", orderID,
+    ).Scan(&status)
+    if err != nil {
+        return "", err
+    }
+    return status, nil
+}
+
+func (r *PostgresTaskRepo) QueueDepth(ctx context.Context) (int, error) {
+    var depth int
+    err := r.db.QueryRowContext(ctx, "SELECT count(*) FROM tasks WHERE status=$1", model.TaskStatusQueued).Scan(&depth)
+    return depth, err
+}
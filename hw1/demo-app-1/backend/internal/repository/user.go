@@ -0,0 +1,108 @@
+package repository
+
+import (
+    "context"
+    "database/sql"
+    "fmt"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+)
+
+// UserSortColumns is the whitelist of columns UserRepository.List may sort by.
+var UserSortColumns = []string{"id", "name", "email", "created_at"}
+
+// UserListParams are the filters accepted by UserRepository.List, on top of
+// the shared ListParams pagination/sorting.
+type UserListParams struct {
+    ListParams
+    EmailPrefix string
+}
+
+// UserRepository is the storage interface for User records. The service
+// layer takes an implementation at construction; PostgresUserRepo is the
+// only one here.
+type UserRepository interface {
+    Create(ctx context.Context, u *model.User) error
+    Get(ctx context.Context, id int) (*model.User, error)
+    List(ctx context.Context, params UserListParams) ([]model.User, int, error)
+    Update(ctx context.Context, u *model.User) error
+    Delete(ctx context.Context, id int) error
+}
+
+// PostgresUserRepo implements UserRepository backed by database/sql.
+type PostgresUserRepo struct {
+    db *sql.DB
+}
+
+func NewPostgresUserRepo(db *sql.DB) *PostgresUserRepo {
+    return &PostgresUserRepo{db: db}
+}
+
+func (r *PostgresUserRepo) Create(ctx context.Context, u *model.User) error {
+    return r.db.QueryRowContext(ctx,
+        "INSERT INTO users (name, email) VALUES ($1, $2) RETURNING id, created_at",
+        u.Name, u.Email,
+    ).Scan(&u.ID, &u.CreatedAt)
+}
+
+func (r *PostgresUserRepo) Get(ctx context.Context, id int) (*model.User, error) {
+    var u model.User
+    err := r.db.QueryRowContext(ctx,
+        "SELECT id, name, email, created_at FROM users WHERE id=$1", id,
+    ).Scan(&u.ID, &u.Name, &u.Email, &u.CreatedAt)
+    if err != nil {
+        return nil, err
+    }
+    return &u, nil
+}
+
+// List returns the page of users matching params, plus the total count of
+// matching rows (ignoring Limit/Offset) for the X-Total-Count header.
+func (r *PostgresUserRepo) List(ctx context.Context, params UserListParams) ([]model.User, int, error) {
+    where := ""
+    args := []interface{}{}
+    if params.EmailPrefix != "" {
+        where = "WHERE email LIKE $1"
+        args = append(args, params.EmailPrefix+"%")
+    }
+
+    var total int
+    countQuery := fmt.Sprintf("SELECT count(*) FROM users %s", where)
+    if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+        return nil, 0, err
+    }
+
+    limitArg := len(args) + 1
+    offsetArg := len(args) + 2
+    query := fmt.Sprintf(
+        "SELECT id, name, email, created_at FROM users %s %s LIMIT $%d OFFSET $%d",
+        where, params.OrderByClause(), limitArg, offsetArg,
+    )
+    args = append(args, params.Limit, params.Offset)
+
+    rows, err := r.db.QueryContext(ctx, query, args...)
+    if err != nil {
+        return nil, 0, err
+    }
+    defer rows.Close()
+
+    users := []model.User{}
+    for rows.Next() {
+        var u model.User
+        if err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.CreatedAt); err != nil {
+            return nil, 0, err
+        }
+        users = append(users, u)
+    }
+    return users, total, rows.Err()
+}
+
+func (r *PostgresUserRepo) Update(ctx context.Context, u *model.User) error {
+    _, err := r.db.ExecContext(ctx, "UPDATE users SET name=$1, email=$2 WHERE id=$3", u.Name, u.Email, u.ID)
+    return err
+}
+
+func (r *PostgresUserRepo) Delete(ctx context.Context, id int) error {
+    _, err := r.db.ExecContext(ctx, "DELETE FROM users WHERE id=$1", id)
+    return err
+}
@@ -0,0 +1,32 @@
+package service
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Metrics holds the Prometheus collectors shared by the service layer. It is
+// constructed once in main and injected into each service so tests can build
+// one on their own registry without touching the global default registerer.
+type Metrics struct {
+    DBQueryDuration prometheus.Histogram
+    ActiveRequests  prometheus.Gauge
+    DBQueryTimeouts prometheus.Counter
+}
+
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+    m := &Metrics{
+        DBQueryDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+            Name:    "db_query_duration_seconds",
+            Help:    "Database query durations",
+            Buckets: prometheus.DefBuckets,
+        }),
+        ActiveRequests: prometheus.NewGauge(prometheus.GaugeOpts{
+            Name: "service_active_requests",
+            Help: "Number of service calls currently in flight",
+        }),
+        DBQueryTimeouts: prometheus.NewCounter(prometheus.CounterOpts{
+            Name: "db_query_timeouts_total",
+            Help: "Total number of DB queries that hit their per-request deadline",
+        }),
+    }
+    reg.MustRegister(m.DBQueryDuration, m.ActiveRequests, m.DBQueryTimeouts)
+    return m
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/config"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/repository"
+)
+
+// UserService contains the business logic for users. Every repository call
+// is bounded by cfg.DBTimeout and instrumented with the shared metrics.
+type UserService struct {
+    repo    repository.UserRepository
+    metrics *Metrics
+    cfg     config.Config
+}
+
+func NewUserService(repo repository.UserRepository, metrics *Metrics, cfg config.Config) *UserService {
+    return &UserService{repo: repo, metrics: metrics, cfg: cfg}
+}
+
+func (s *UserService) withDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+    return context.WithTimeout(ctx, s.cfg.DBTimeout)
+}
+
+func (s *UserService) observe(start time.Time, err error) {
+    s.metrics.DBQueryDuration.Observe(time.Since(start).Seconds())
+    if errors.Is(err, context.DeadlineExceeded) {
+        s.metrics.DBQueryTimeouts.Inc()
+    }
+}
+
+func (s *UserService) CreateUser(ctx context.Context, u *model.User) error {
+    s.metrics.ActiveRequests.Inc()
+    defer s.metrics.ActiveRequests.Dec()
+    ctx, cancel := s.withDeadline(ctx)
+    defer cancel()
+    start := time.Now()
+    err := s.repo.Create(ctx, u)
+    s.observe(start, err)
+    return err
+}
+
+func (s *UserService) GetUser(ctx context.Context, id int) (*model.User, error) {
+    s.metrics.ActiveRequests.Inc()
+    defer s.metrics.ActiveRequests.Dec()
+    ctx, cancel := s.withDeadline(ctx)
+    defer cancel()
+    start := time.Now()
+    u, err := s.repo.Get(ctx, id)
+    s.observe(start, err)
+    return u, err
+}
+
+func (s *UserService) ListUsers(ctx context.Context, params repository.UserListParams) ([]model.User, int, error) {
+    s.metrics.ActiveRequests.Inc()
+    defer s.metrics.ActiveRequests.Dec()
+    ctx, cancel := s.withDeadline(ctx)
+    defer cancel()
+    start := time.Now()
+    users, total, err := s.repo.List(ctx, params)
+    s.observe(start, err)
+    return users, total, err
+}
+
+func (s *UserService) UpdateUser(ctx context.Context, u *model.User) error {
+    s.metrics.ActiveRequests.Inc()
+    defer s.metrics.ActiveRequests.Dec()
+    ctx, cancel := s.withDeadline(ctx)
+    defer cancel()
+    start := time.Now()
+    err := s.repo.Update(ctx, u)
+    s.observe(start, err)
+    return err
+}
+
+func (s *UserService) DeleteUser(ctx context.Context, id int) error {
+    s.metrics.ActiveRequests.Inc()
+    defer s.metrics.ActiveRequests.Dec()
+    ctx, cancel := s.withDeadline(ctx)
+    defer cancel()
+    start := time.Now()
+    err := s.repo.Delete(ctx, id)
+    s.observe(start, err)
+    return err
+}
@@ -0,0 +1,28 @@
+package worker
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+    queueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+        Name: "task_queue_depth",
+        Help: "Number of tasks currently queued for processing",
+    })
+
+    processedTotal = prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "task_processed_total",
+            Help: "Total number of tasks processed, by outcome",
+        },
+        []string{"status"},
+    )
+
+    taskDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+        Name:    "task_duration_seconds",
+        Help:    "Time spent processing a task end to end",
+        Buckets: prometheus.DefBuckets,
+    })
+)
+
+func init() {
+    prometheus.MustRegister(queueDepth, processedTotal, taskDuration)
+}
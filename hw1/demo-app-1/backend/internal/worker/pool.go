@@ -0,0 +1,134 @@
+package worker
+
+import (
+    "context"
+    "database/sql"
+    "log"
+    "time"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/repository"
+)
+
+// Pool is a fixed-size worker pool that drains order.process tasks. Workers
+// claim rows from TaskRepository (which uses SELECT ... FOR UPDATE SKIP
+// LOCKED), so several Pools across replicas can share one queue. A task
+// that fails processing is requeued with a backoff until it has been tried
+// model.MaxTaskAttempts times, after which it's left in TaskStatusFailed.
+// The buffered wake channel just lets CreateOrder nudge an idle worker
+// instead of waiting for the poll interval.
+type Pool struct {
+    tasks     repository.TaskRepository
+    orders    repository.OrderRepository
+    workers   int
+    wake      chan struct{}
+    pollEvery time.Duration
+}
+
+func NewPool(tasks repository.TaskRepository, orders repository.OrderRepository, workers int) *Pool {
+    return &Pool{
+        tasks:     tasks,
+        orders:    orders,
+        workers:   workers,
+        wake:      make(chan struct{}, 1),
+        pollEvery: time.Second,
+    }
+}
+
+// Notify wakes an idle worker to look for new work immediately. It is safe
+// to call when the pool is already busy; the channel is a 1-slot coalescing
+// signal, not a work queue.
+func (p *Pool) Notify() {
+    select {
+    case p.wake <- struct{}{}:
+    default:
+    }
+}
+
+// Run starts the workers and blocks until ctx is cancelled.
+func (p *Pool) Run(ctx context.Context) {
+    for i := 0; i < p.workers; i++ {
+        go p.runWorker(ctx)
+    }
+    <-ctx.Done()
+}
+
+func (p *Pool) runWorker(ctx context.Context) {
+    ticker := time.NewTicker(p.pollEvery)
+    defer ticker.Stop()
+
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-p.wake:
+        case <-ticker.C:
+            p.reportQueueDepth(ctx)
+        }
+        for p.claimAndProcessOne(ctx) {
+        }
+    }
+}
+
+func (p *Pool) reportQueueDepth(ctx context.Context) {
+    depth, err := p.tasks.QueueDepth(ctx)
+    if err != nil {
+        log.Println("worker: queue depth:", err)
+        return
+    }
+    queueDepth.Set(float64(depth))
+}
+
+// claimAndProcessOne claims a single task and processes it, returning true
+// if a task was found (so the caller can immediately try for another).
+func (p *Pool) claimAndProcessOne(ctx context.Context) bool {
+    task, err := p.tasks.ClaimNext(ctx)
+    if err == sql.ErrNoRows {
+        return false
+    }
+    if err != nil {
+        log.Println("worker: claim task:", err)
+        return false
+    }
+
+    start := time.Now()
+    err = p.process(ctx, task)
+    taskDuration.Observe(time.Since(start).Seconds())
+
+    if err != nil {
+        log.Println("worker: process task", task.ID, ":", err)
+        if markErr := p.tasks.MarkFailed(ctx, task, err); markErr != nil {
+            log.Println("worker: mark failed:", markErr)
+        }
+        outcome := "retry"
+        if task.Attempts >= model.MaxTaskAttempts {
+            outcome = string(model.TaskStatusFailed)
+        }
+        processedTotal.WithLabelValues(outcome).Inc()
+        return true
+    }
+
+    if err := p.tasks.MarkDone(ctx, task.ID); err != nil {
+        log.Println("worker: mark done:", err)
+    }
+    processedTotal.WithLabelValues(string(model.TaskStatusDone)).Inc()
+    return true
+}
+
+// process performs the order.process enrichment: loading the order (failing
+// if it can't be read), calling the (stub) payment webhook, and marking the
+// order's status "processed". It does not recompute totals.
+func (p *Pool) process(ctx context.Context, task *model.Task) error {
+    if _, err := p.orders.Get(ctx, task.OrderID); err != nil {
+        return err
+    }
+    if err := notifyPaymentWebhook(task.OrderID); err != nil {
+        return err
+    }
+    return p.orders.UpdateStatus(ctx, task.OrderID, "processed")
+}
+
+// notifyPaymentWebhook stands in for a call to a real payment provider.
+func notifyPaymentWebhook(orderID int) error {
+    return nil
+}
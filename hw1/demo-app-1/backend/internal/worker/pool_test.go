@@ -0,0 +1,169 @@
+package worker
+
+import (
+    "context"
+    "database/sql"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/model"
+    "github.com/mkozakevich/system-design-hw/hw1/demo-app-1/backend/internal/repository"
+)
+
+// fakeTaskRepo is an in-memory repository.TaskRepository for exercising the
+// worker pool without a database.
+type fakeTaskRepo struct {
+    tasks      map[int]*model.Task
+    nextID     int
+    markedDone []int
+    markedFail []int
+}
+
+func newFakeTaskRepo() *fakeTaskRepo {
+    return &fakeTaskRepo{tasks: map[int]*model.Task{}}
+}
+
+func (f *fakeTaskRepo) Enqueue(ctx context.Context, kind string, orderID int) (*model.Task, error) {
+    f.nextID++
+    t := &model.Task{ID: f.nextID, Kind: kind, OrderID: orderID, Status: model.TaskStatusQueued, NextRun: time.Now()}
+    f.tasks[t.ID] = t
+    return t, nil
+}
+
+func (f *fakeTaskRepo) ClaimNext(ctx context.Context) (*model.Task, error) {
+    for _, t := range f.tasks {
+        if t.Status == model.TaskStatusQueued && !t.NextRun.After(time.Now()) {
+            t.Status = model.TaskStatusProcessing
+            t.Attempts++
+            claimed := *t
+            return &claimed, nil
+        }
+    }
+    return nil, sql.ErrNoRows
+}
+
+func (f *fakeTaskRepo) MarkDone(ctx context.Context, id int) error {
+    f.markedDone = append(f.markedDone, id)
+    f.tasks[id].Status = model.TaskStatusDone
+    return nil
+}
+
+func (f *fakeTaskRepo) MarkFailed(ctx context.Context, task *model.Task, cause error) error {
+    f.markedFail = append(f.markedFail, task.ID)
+    stored := f.tasks[task.ID]
+    stored.Attempts = task.Attempts
+    stored.LastError = cause.Error()
+    if task.Attempts >= model.MaxTaskAttempts {
+        stored.Status = model.TaskStatusFailed
+    } else {
+        stored.Status = model.TaskStatusQueued
+        stored.NextRun = time.Now().Add(time.Minute)
+    }
+    return nil
+}
+
+func (f *fakeTaskRepo) StatusByOrderID(ctx context.Context, orderID int) (model.TaskStatus, error) {
+    for _, t := range f.tasks {
+        if t.OrderID == orderID {
+            return t.Status, nil
+        }
+    }
+    return "", sql.ErrNoRows
+}
+
+func (f *fakeTaskRepo) QueueDepth(ctx context.Context) (int, error) {
+    depth := 0
+    for _, t := range f.tasks {
+        if t.Status == model.TaskStatusQueued {
+            depth++
+        }
+    }
+    return depth, nil
+}
+
+var _ repository.TaskRepository = (*fakeTaskRepo)(nil)
+
+// fakeOrderRepo is an in-memory repository.OrderRepository. getErr lets a
+// test force process() to fail.
+type fakeOrderRepo struct {
+    order  *model.Order
+    getErr error
+    status string
+}
+
+func (f *fakeOrderRepo) Create(ctx context.Context, o *model.Order) error { return nil }
+
+func (f *fakeOrderRepo) Get(ctx context.Context, id int) (*model.Order, error) {
+    if f.getErr != nil {
+        return nil, f.getErr
+    }
+    return f.order, nil
+}
+
+func (f *fakeOrderRepo) List(ctx context.Context, params repository.OrderListParams) ([]model.Order, int, error) {
+    return nil, 0, nil
+}
+func (f *fakeOrderRepo) Update(ctx context.Context, o *model.Order) error { return nil }
+func (f *fakeOrderRepo) Delete(ctx context.Context, id int) error        { return nil }
+
+func (f *fakeOrderRepo) UpdateStatus(ctx context.Context, id int, status string) error {
+    f.status = status
+    return nil
+}
+
+var _ repository.OrderRepository = (*fakeOrderRepo)(nil)
+
+func TestClaimAndProcessOne_Done(t *testing.T) {
+    tasks := newFakeTaskRepo()
+    task, _ := tasks.Enqueue(context.Background(), "order.process", 7)
+    orders := &fakeOrderRepo{order: &model.Order{ID: 7}}
+
+    p := NewPool(tasks, orders, 1)
+
+    if !p.claimAndProcessOne(context.Background()) {
+        t.Fatal("expected a task to be claimed")
+    }
+    if orders.status != "processed" {
+        t.Fatalf("expected order to be marked processed, got %q", orders.status)
+    }
+    if len(tasks.markedDone) != 1 || tasks.markedDone[0] != task.ID {
+        t.Fatalf("expected task %d to be marked done, got %v", task.ID, tasks.markedDone)
+    }
+    if tasks.tasks[task.ID].Status != model.TaskStatusDone {
+        t.Fatalf("expected stored status done, got %s", tasks.tasks[task.ID].Status)
+    }
+}
+
+func TestClaimAndProcessOne_RetriesUntilMaxAttempts(t *testing.T) {
+    tasks := newFakeTaskRepo()
+    task, _ := tasks.Enqueue(context.Background(), "order.process", 7)
+    orders := &fakeOrderRepo{getErr: errors.New("order lookup failed")}
+
+    p := NewPool(tasks, orders, 1)
+
+    for attempt := 1; attempt <= model.MaxTaskAttempts; attempt++ {
+        tasks.tasks[task.ID].NextRun = time.Now()
+        if !p.claimAndProcessOne(context.Background()) {
+            t.Fatalf("attempt %d: expected a task to be claimed", attempt)
+        }
+    }
+
+    stored := tasks.tasks[task.ID]
+    if stored.Status != model.TaskStatusFailed {
+        t.Fatalf("expected task to be terminally failed after %d attempts, got %s", model.MaxTaskAttempts, stored.Status)
+    }
+    if stored.Attempts != model.MaxTaskAttempts {
+        t.Fatalf("expected %d attempts, got %d", model.MaxTaskAttempts, stored.Attempts)
+    }
+}
+
+func TestClaimAndProcessOne_NoTasksQueued(t *testing.T) {
+    tasks := newFakeTaskRepo()
+    orders := &fakeOrderRepo{}
+    p := NewPool(tasks, orders, 1)
+
+    if p.claimAndProcessOne(context.Background()) {
+        t.Fatal("expected no task to be claimed from an empty queue")
+    }
+}